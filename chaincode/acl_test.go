@@ -0,0 +1,56 @@
+package chaincode_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tingui96/cc-medicalregisters-go/chaincode"
+	"github.com/tingui96/cc-medicalregisters-go/chaincode/mocks"
+)
+
+func TestInitLedgerCannotRunTwice(t *testing.T) {
+	transactionContext := mocks.NewTransactionContext()
+	contract := chaincode.SmartContract{}
+
+	require.NoError(t, contract.InitLedger(transactionContext))
+	assert.Error(t, contract.InitLedger(transactionContext))
+}
+
+func TestSetACLAddsAThirdOrgToCreateAsset(t *testing.T) {
+	transactionContext := mocks.NewTransactionContext()
+	transactionContext.ClientIdentity.MSPID = "Org1MSP"
+
+	contract := chaincode.SmartContract{}
+	require.NoError(t, contract.SetACL(transactionContext, "CreateAsset", []string{"Org1MSP", "Org3MSP"}))
+
+	transactionContext.ClientIdentity.MSPID = "Org3MSP"
+	err := contract.CreateAsset(transactionContext, "asset1", "Pepe", "Good patient", 1, "Lozano")
+
+	assert.NoError(t, err)
+}
+
+func TestSetACLRejectsAnUnrecognizedOrg(t *testing.T) {
+	transactionContext := mocks.NewTransactionContext()
+	transactionContext.ClientIdentity.MSPID = "Org3MSP"
+
+	contract := chaincode.SmartContract{}
+	err := contract.SetACL(transactionContext, "CreateAsset", []string{"Org3MSP"})
+
+	assert.Error(t, err)
+}
+
+func TestGetAllAssetsIgnoresACLConfigInWorldState(t *testing.T) {
+	transactionContext := mocks.NewTransactionContext()
+	transactionContext.ClientIdentity.MSPID = "Org1MSP"
+
+	contract := chaincode.SmartContract{}
+	require.NoError(t, contract.CreateAsset(transactionContext, "asset1", "Pepe", "Good patient", 1, "Lozano"))
+	require.NoError(t, contract.SetACL(transactionContext, "CreateAsset", []string{"Org1MSP", "Org3MSP"}))
+
+	assets, err := contract.GetAllAssets(transactionContext)
+
+	require.NoError(t, err)
+	assert.Len(t, assets, 1)
+}
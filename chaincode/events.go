@@ -0,0 +1,47 @@
+package chaincode
+
+import (
+	"encoding/json"
+
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+// Event names emitted by the contract so off-chain services (notifications,
+// dashboards, downstream EHR systems) can react to lifecycle transitions
+// without polling GetAllAssets.
+const (
+	eventAssetCreated      = "AssetCreated"
+	eventAssetStateChanged = "AssetStateChanged"
+	eventAssetTransferred  = "AssetTransferred"
+	eventAssetDeleted      = "AssetDeleted"
+)
+
+// assetStateChangedPayload is the JSON payload for AssetStateChanged events.
+type assetStateChangedPayload struct {
+	ID       string `json:"id"`
+	OldState int    `json:"oldState"`
+	NewState int    `json:"newState"`
+}
+
+// assetTransferredPayload is the JSON payload for AssetTransferred events.
+type assetTransferredPayload struct {
+	ID       string `json:"id"`
+	OldGroup string `json:"oldGroup"`
+	NewGroup string `json:"newGroup"`
+	MSPID    string `json:"mspID"`
+}
+
+// assetDeletedPayload is the JSON payload for AssetDeleted events.
+type assetDeletedPayload struct {
+	ID string `json:"id"`
+}
+
+// emitEvent marshals payload to JSON and sets it as a chaincode event.
+func emitEvent(ctx contractapi.TransactionContextInterface, name string, payload interface{}) error {
+	payloadJSON, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	return ctx.GetStub().SetEvent(name, payloadJSON)
+}
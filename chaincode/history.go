@@ -0,0 +1,59 @@
+package chaincode
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+// AssetHistoryEntry represents a single modification of an asset as recorded
+// on the blockchain. Deletions show up with IsDelete set and Value left nil,
+// so a tombstone left behind by DeleteAsset is as visible to an auditor as
+// any CreateAsset/UpdateAsset entry.
+type AssetHistoryEntry struct {
+	TxID      string         `json:"TxID"`
+	Timestamp time.Time      `json:"Timestamp"`
+	IsDelete  bool           `json:"IsDelete"`
+	Value     *ClinicHistory `json:"Value"`
+}
+
+// GetAssetHistory returns the full change history of an asset, decoded from
+// the blockchain's GetHistoryForKey results in the order the history
+// iterator yields them; the entries are not re-sorted. A DelState followed
+// by a later PutState under the same ID (a "zombie key") shows up here as a
+// tombstone entry and a fresh creation entry, rather than being hidden from
+// the caller.
+func (s *SmartContract) GetAssetHistory(ctx contractapi.TransactionContextInterface, id string) ([]AssetHistoryEntry, error) {
+	historyIterator, err := ctx.GetStub().GetHistoryForKey(id)
+	if err != nil {
+		return nil, err
+	}
+	defer historyIterator.Close()
+
+	var history []AssetHistoryEntry
+	for historyIterator.HasNext() {
+		modification, err := historyIterator.Next()
+		if err != nil {
+			return nil, err
+		}
+
+		entry := AssetHistoryEntry{
+			TxID:      modification.TxId,
+			Timestamp: time.Unix(modification.Timestamp.Seconds, int64(modification.Timestamp.Nanos)).UTC(),
+			IsDelete:  modification.IsDelete,
+		}
+
+		if !modification.IsDelete {
+			var asset ClinicHistory
+			if err := json.Unmarshal(modification.Value, &asset); err != nil {
+				return nil, err
+			}
+			entry.Value = &asset
+		}
+
+		history = append(history, entry)
+	}
+
+	return history, nil
+}
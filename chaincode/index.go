@@ -0,0 +1,145 @@
+package chaincode
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+// Composite-key object types used to maintain secondary indexes for peers
+// running on LevelDB, which has no Mango query support (see queries.go for
+// the CouchDB-only equivalent). Both indexes are written as indexMarker
+// entries and are kept in sync on every write that touches Group, State or
+// PatientName.
+const (
+	groupStateIndex = "group~state~id"
+	groupNameIndex  = "group~name~id"
+)
+
+// indexMarker is the value written for every composite-key index entry; the
+// key alone carries the information, so the value is a single null byte
+// (a nil value would delete the key instead of writing it).
+var indexMarker = []byte{0x00}
+
+// putGroupStateIndex adds a group~state~id marker to the public world state
+// so GetAssetsByGroupAndState can walk it with GetStateByPartialCompositeKey.
+func putGroupStateIndex(ctx contractapi.TransactionContextInterface, group string, state int, id string) error {
+	key, err := ctx.GetStub().CreateCompositeKey(groupStateIndex, []string{group, strconv.Itoa(state), id})
+	if err != nil {
+		return err
+	}
+
+	return ctx.GetStub().PutState(key, indexMarker)
+}
+
+// delGroupStateIndex removes a previously written group~state~id marker.
+func delGroupStateIndex(ctx contractapi.TransactionContextInterface, group string, state int, id string) error {
+	key, err := ctx.GetStub().CreateCompositeKey(groupStateIndex, []string{group, strconv.Itoa(state), id})
+	if err != nil {
+		return err
+	}
+
+	return ctx.GetStub().DelState(key)
+}
+
+// putGroupNameIndex adds a group~name~id marker to the clinicHistoryPrivate
+// collection. PatientName is PHI, so unlike groupStateIndex this index is
+// kept inside the private collection rather than on the public ledger -
+// GetAssetsByGroupAndPatientPrefix walks it with
+// GetPrivateDataByPartialCompositeKey instead.
+func putGroupNameIndex(ctx contractapi.TransactionContextInterface, group string, patientName string, id string) error {
+	key, err := ctx.GetStub().CreateCompositeKey(groupNameIndex, []string{group, patientName, id})
+	if err != nil {
+		return err
+	}
+
+	return ctx.GetStub().PutPrivateData(clinicHistoryPrivateCollection, key, indexMarker)
+}
+
+// delGroupNameIndex removes a previously written group~name~id marker.
+func delGroupNameIndex(ctx contractapi.TransactionContextInterface, group string, patientName string, id string) error {
+	key, err := ctx.GetStub().CreateCompositeKey(groupNameIndex, []string{group, patientName, id})
+	if err != nil {
+		return err
+	}
+
+	return ctx.GetStub().DelPrivateData(clinicHistoryPrivateCollection, key)
+}
+
+// GetAssetsByGroupAndState returns every asset in group currently sitting in
+// state, read back through the group~state~id composite-key index. This is
+// the LevelDB-compatible equivalent of QueryAssetsByGroup combined with
+// QueryAssetsByState.
+func (s *SmartContract) GetAssetsByGroupAndState(ctx contractapi.TransactionContextInterface, group string, state int) ([]*ClinicHistory, error) {
+	resultsIterator, err := ctx.GetStub().GetStateByPartialCompositeKey(groupStateIndex, []string{group, strconv.Itoa(state)})
+	if err != nil {
+		return nil, err
+	}
+	defer resultsIterator.Close()
+
+	var assets []*ClinicHistory
+	for resultsIterator.HasNext() {
+		responseRange, err := resultsIterator.Next()
+		if err != nil {
+			return nil, err
+		}
+
+		_, keyParts, err := ctx.GetStub().SplitCompositeKey(responseRange.Key)
+		if err != nil {
+			return nil, err
+		}
+
+		id := keyParts[2]
+		asset, err := s.ReadAsset(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		assets = append(assets, asset)
+	}
+
+	return assets, nil
+}
+
+// GetAssetsByGroupAndPatientPrefix returns every asset in group whose
+// PatientName starts with prefix, read back through the private
+// group~name~id composite-key index. GetPrivateDataByPartialCompositeKey
+// only matches complete attribute segments - passing prefix as a partial
+// attribute would append a trailing delimiter after it and only match
+// PatientName values equal to prefix, not prefixed by it. Instead this
+// walks every entry under group and filters on the decoded PatientName
+// segment with strings.HasPrefix.
+func (s *SmartContract) GetAssetsByGroupAndPatientPrefix(ctx contractapi.TransactionContextInterface, group string, prefix string) ([]*ClinicHistory, error) {
+	resultsIterator, err := ctx.GetStub().GetPrivateDataByPartialCompositeKey(clinicHistoryPrivateCollection, groupNameIndex, []string{group})
+	if err != nil {
+		return nil, err
+	}
+	defer resultsIterator.Close()
+
+	var assets []*ClinicHistory
+	for resultsIterator.HasNext() {
+		responseRange, err := resultsIterator.Next()
+		if err != nil {
+			return nil, err
+		}
+
+		_, keyParts, err := ctx.GetStub().SplitCompositeKey(responseRange.Key)
+		if err != nil {
+			return nil, err
+		}
+
+		patientName := keyParts[1]
+		if !strings.HasPrefix(patientName, prefix) {
+			continue
+		}
+
+		id := keyParts[2]
+		asset, err := s.ReadAsset(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		assets = append(assets, asset)
+	}
+
+	return assets, nil
+}
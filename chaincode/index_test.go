@@ -0,0 +1,43 @@
+package chaincode_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tingui96/cc-medicalregisters-go/chaincode"
+	"github.com/tingui96/cc-medicalregisters-go/chaincode/mocks"
+)
+
+func TestGetAssetsByGroupAndStateFollowsTheIndex(t *testing.T) {
+	transactionContext := mocks.NewTransactionContext()
+	transactionContext.ClientIdentity.MSPID = "Org1MSP"
+
+	contract := chaincode.SmartContract{}
+	require.NoError(t, contract.CreateAsset(transactionContext, "asset1", "Pepe", "Good patient", 1, "Lozano"))
+	require.NoError(t, contract.CreateAsset(transactionContext, "asset2", "Ana", "Good patient", 1, "Lozano"))
+	require.NoError(t, contract.CreateAsset(transactionContext, "asset3", "Juan", "Good patient", 1, "ASP"))
+
+	assets, err := contract.GetAssetsByGroupAndState(transactionContext, "Lozano", 1)
+
+	require.NoError(t, err)
+	ids := []string{assets[0].ID, assets[1].ID}
+	assert.ElementsMatch(t, []string{"asset1", "asset2"}, ids)
+}
+
+func TestGetAssetsByGroupAndPatientPrefixFollowsThePrivateIndex(t *testing.T) {
+	transactionContext := mocks.NewTransactionContext()
+	transactionContext.ClientIdentity.MSPID = "Org1MSP"
+
+	contract := chaincode.SmartContract{}
+	require.NoError(t, contract.CreateAsset(transactionContext, "asset1", "Pepe", "Good patient", 1, "Lozano"))
+	require.NoError(t, contract.CreateAsset(transactionContext, "asset2", "Pepito", "Good patient", 1, "Lozano"))
+	require.NoError(t, contract.CreateAsset(transactionContext, "asset3", "Ana", "Good patient", 1, "Lozano"))
+
+	assets, err := contract.GetAssetsByGroupAndPatientPrefix(transactionContext, "Lozano", "Pep")
+
+	require.NoError(t, err)
+	ids := []string{assets[0].ID, assets[1].ID}
+	assert.ElementsMatch(t, []string{"asset1", "asset2"}, ids)
+}
@@ -0,0 +1,242 @@
+// Package mocks provides hand-written fakes for the Fabric chaincode
+// interfaces (ChaincodeStubInterface, TransactionContextInterface,
+// StateQueryIteratorInterface) so the contract's business logic can be unit
+// tested without a running peer, following the same
+// stub/transactioncontext/iterator split used by the Fabric
+// asset-transfer-basic sample's counterfeiter-generated mocks.
+package mocks
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"sort"
+	"strings"
+
+	"github.com/hyperledger/fabric-chaincode-go/shim"
+	"github.com/hyperledger/fabric-protos-go/ledger/queryresult"
+	"github.com/hyperledger/fabric-protos-go/peer"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const compositeKeyDelimiter = "\x00"
+
+// ChaincodeStub is an in-memory fake of shim.ChaincodeStubInterface. World
+// state and every private collection are each backed by a plain map, so
+// tests can PutState/PutPrivateData and immediately read back what they
+// wrote without standing up a peer.
+type ChaincodeStub struct {
+	State        map[string][]byte
+	PrivateState map[string]map[string][]byte
+	History      map[string][]*queryresult.KeyModification
+
+	TxIDReturns string
+	Events      []Event
+}
+
+// Event records a single SetEvent call.
+type Event struct {
+	Name    string
+	Payload []byte
+}
+
+// NewChaincodeStub returns a ChaincodeStub ready to use, with its state maps
+// initialized.
+func NewChaincodeStub() *ChaincodeStub {
+	return &ChaincodeStub{
+		State:        map[string][]byte{},
+		PrivateState: map[string]map[string][]byte{},
+		History:      map[string][]*queryresult.KeyModification{},
+	}
+}
+
+func (f *ChaincodeStub) GetState(key string) ([]byte, error) {
+	return f.State[key], nil
+}
+
+func (f *ChaincodeStub) PutState(key string, value []byte) error {
+	f.State[key] = value
+	f.History[key] = append(f.History[key], &queryresult.KeyModification{
+		TxId:      f.TxIDReturns,
+		Value:     value,
+		Timestamp: timestamppb.Now(),
+		IsDelete:  false,
+	})
+	return nil
+}
+
+func (f *ChaincodeStub) DelState(key string) error {
+	delete(f.State, key)
+	f.History[key] = append(f.History[key], &queryresult.KeyModification{
+		TxId:      f.TxIDReturns,
+		Timestamp: timestamppb.Now(),
+		IsDelete:  true,
+	})
+	return nil
+}
+
+func (f *ChaincodeStub) GetPrivateData(collection, key string) ([]byte, error) {
+	return f.PrivateState[collection][key], nil
+}
+
+func (f *ChaincodeStub) GetPrivateDataHash(collection, key string) ([]byte, error) {
+	value := f.PrivateState[collection][key]
+	if value == nil {
+		return nil, nil
+	}
+	hash := sha256.Sum256(value)
+	return hash[:], nil
+}
+
+func (f *ChaincodeStub) PutPrivateData(collection string, key string, value []byte) error {
+	if f.PrivateState[collection] == nil {
+		f.PrivateState[collection] = map[string][]byte{}
+	}
+	f.PrivateState[collection][key] = value
+	return nil
+}
+
+func (f *ChaincodeStub) DelPrivateData(collection, key string) error {
+	delete(f.PrivateState[collection], key)
+	return nil
+}
+
+func (f *ChaincodeStub) PurgePrivateData(collection, key string) error {
+	delete(f.PrivateState[collection], key)
+	return nil
+}
+
+func (f *ChaincodeStub) SetEvent(name string, payload []byte) error {
+	f.Events = append(f.Events, Event{Name: name, Payload: payload})
+	return nil
+}
+
+func (f *ChaincodeStub) GetStateByRange(startKey, endKey string) (shim.StateQueryIteratorInterface, error) {
+	var kvs []*queryresult.KV
+	for _, key := range f.sortedStateKeys() {
+		if (startKey == "" || key >= startKey) && (endKey == "" || key < endKey) {
+			kvs = append(kvs, &queryresult.KV{Key: key, Value: f.State[key]})
+		}
+	}
+	return NewStateQueryIterator(kvs), nil
+}
+
+func (f *ChaincodeStub) GetStateByPartialCompositeKey(objectType string, keys []string) (shim.StateQueryIteratorInterface, error) {
+	prefix, _ := f.CreateCompositeKey(objectType, keys)
+	var kvs []*queryresult.KV
+	for _, key := range f.sortedStateKeys() {
+		if strings.HasPrefix(key, prefix) {
+			kvs = append(kvs, &queryresult.KV{Key: key, Value: f.State[key]})
+		}
+	}
+	return NewStateQueryIterator(kvs), nil
+}
+
+// GetQueryResult is a best-effort fake: it cannot evaluate a real Mango
+// selector without CouchDB, so it returns every world-state entry (index
+// markers, ACL config and the initialized marker included) and leaves
+// selector filtering to the caller's own test assertions.
+func (f *ChaincodeStub) GetQueryResult(query string) (shim.StateQueryIteratorInterface, error) {
+	return f.GetStateByRange("", "")
+}
+
+func (f *ChaincodeStub) GetQueryResultWithPagination(query string, pageSize int32, bookmark string) (shim.StateQueryIteratorInterface, *peer.QueryResponseMetadata, error) {
+	iterator, err := f.GetStateByRange("", "")
+	return iterator, &peer.QueryResponseMetadata{FetchedRecordsCount: int32(len(f.State)), Bookmark: bookmark}, err
+}
+
+func (f *ChaincodeStub) GetHistoryForKey(key string) (shim.HistoryQueryIteratorInterface, error) {
+	return NewHistoryQueryIterator(f.History[key]), nil
+}
+
+func (f *ChaincodeStub) CreateCompositeKey(objectType string, attributes []string) (string, error) {
+	var buffer bytes.Buffer
+	buffer.WriteString(compositeKeyDelimiter)
+	buffer.WriteString(objectType)
+	for _, attribute := range attributes {
+		buffer.WriteString(compositeKeyDelimiter)
+		buffer.WriteString(attribute)
+	}
+	buffer.WriteString(compositeKeyDelimiter)
+	return buffer.String(), nil
+}
+
+func (f *ChaincodeStub) sortedStateKeys() []string {
+	keys := make([]string, 0, len(f.State))
+	for key := range f.State {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func (f *ChaincodeStub) sortedPrivateStateKeys(collection string) []string {
+	keys := make([]string, 0, len(f.PrivateState[collection]))
+	for key := range f.PrivateState[collection] {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Most of the remaining methods of shim.ChaincodeStubInterface are not
+// exercised by this contract's unit tests and are stubbed out to satisfy the
+// interface. SplitCompositeKey and GetPrivateDataByPartialCompositeKey are
+// real in-memory implementations, used by the composite-key index tests.
+func (f *ChaincodeStub) GetArgs() [][]byte                            { return nil }
+func (f *ChaincodeStub) GetStringArgs() []string                      { return nil }
+func (f *ChaincodeStub) GetFunctionAndParameters() (string, []string) { return "", nil }
+func (f *ChaincodeStub) GetArgsSlice() ([]byte, error)                { return nil, nil }
+func (f *ChaincodeStub) GetTxID() string                              { return f.TxIDReturns }
+func (f *ChaincodeStub) GetChannelID() string                         { return "" }
+func (f *ChaincodeStub) InvokeChaincode(chaincodeName string, args [][]byte, channel string) peer.Response {
+	return peer.Response{}
+}
+func (f *ChaincodeStub) SetStateValidationParameter(key string, ep []byte) error { return nil }
+func (f *ChaincodeStub) GetStateValidationParameter(key string) ([]byte, error)  { return nil, nil }
+func (f *ChaincodeStub) GetStateByRangeWithPagination(startKey, endKey string, pageSize int32, bookmark string) (shim.StateQueryIteratorInterface, *peer.QueryResponseMetadata, error) {
+	iterator, err := f.GetStateByRange(startKey, endKey)
+	return iterator, &peer.QueryResponseMetadata{Bookmark: bookmark}, err
+}
+func (f *ChaincodeStub) GetStateByPartialCompositeKeyWithPagination(objectType string, keys []string, pageSize int32, bookmark string) (shim.StateQueryIteratorInterface, *peer.QueryResponseMetadata, error) {
+	iterator, err := f.GetStateByPartialCompositeKey(objectType, keys)
+	return iterator, &peer.QueryResponseMetadata{Bookmark: bookmark}, err
+}
+func (f *ChaincodeStub) SplitCompositeKey(compositeKey string) (string, []string, error) {
+	parts := strings.Split(strings.Trim(compositeKey, compositeKeyDelimiter), compositeKeyDelimiter)
+	if len(parts) == 0 {
+		return "", nil, nil
+	}
+	return parts[0], parts[1:], nil
+}
+func (f *ChaincodeStub) SetPrivateDataValidationParameter(collection, key string, ep []byte) error {
+	return nil
+}
+func (f *ChaincodeStub) GetPrivateDataValidationParameter(collection, key string) ([]byte, error) {
+	return nil, nil
+}
+func (f *ChaincodeStub) GetPrivateDataByRange(collection, startKey, endKey string) (shim.StateQueryIteratorInterface, error) {
+	return NewStateQueryIterator(nil), nil
+}
+func (f *ChaincodeStub) GetPrivateDataByPartialCompositeKey(collection, objectType string, keys []string) (shim.StateQueryIteratorInterface, error) {
+	prefix, _ := f.CreateCompositeKey(objectType, keys)
+	var kvs []*queryresult.KV
+	for _, key := range f.sortedPrivateStateKeys(collection) {
+		if strings.HasPrefix(key, prefix) {
+			kvs = append(kvs, &queryresult.KV{Key: key, Value: f.PrivateState[collection][key]})
+		}
+	}
+	return NewStateQueryIterator(kvs), nil
+}
+func (f *ChaincodeStub) GetPrivateDataQueryResult(collection, query string) (shim.StateQueryIteratorInterface, error) {
+	return NewStateQueryIterator(nil), nil
+}
+func (f *ChaincodeStub) GetCreator() ([]byte, error)                      { return nil, nil }
+func (f *ChaincodeStub) GetTransient() (map[string][]byte, error)         { return nil, nil }
+func (f *ChaincodeStub) GetBinding() ([]byte, error)                      { return nil, nil }
+func (f *ChaincodeStub) GetDecorations() map[string][]byte                { return nil }
+func (f *ChaincodeStub) GetSignedProposal() (*peer.SignedProposal, error) { return nil, nil }
+func (f *ChaincodeStub) GetTxTimestamp() (*timestamppb.Timestamp, error) {
+	return timestamppb.Now(), nil
+}
+
+var _ shim.ChaincodeStubInterface = (*ChaincodeStub)(nil)
@@ -0,0 +1,56 @@
+package mocks
+
+import "github.com/hyperledger/fabric-protos-go/ledger/queryresult"
+
+// StateQueryIterator is an in-memory fake of shim.StateQueryIteratorInterface
+// that walks a fixed slice of results handed to it by ChaincodeStub.
+type StateQueryIterator struct {
+	results []*queryresult.KV
+	cursor  int
+}
+
+// NewStateQueryIterator returns a StateQueryIterator over results.
+func NewStateQueryIterator(results []*queryresult.KV) *StateQueryIterator {
+	return &StateQueryIterator{results: results}
+}
+
+func (it *StateQueryIterator) HasNext() bool {
+	return it.cursor < len(it.results)
+}
+
+func (it *StateQueryIterator) Next() (*queryresult.KV, error) {
+	result := it.results[it.cursor]
+	it.cursor++
+	return result, nil
+}
+
+func (it *StateQueryIterator) Close() error {
+	return nil
+}
+
+// HistoryQueryIterator is an in-memory fake of
+// shim.HistoryQueryIteratorInterface that walks a fixed slice of key
+// modifications handed to it by ChaincodeStub.
+type HistoryQueryIterator struct {
+	results []*queryresult.KeyModification
+	cursor  int
+}
+
+// NewHistoryQueryIterator returns a HistoryQueryIterator over results.
+func NewHistoryQueryIterator(results []*queryresult.KeyModification) *HistoryQueryIterator {
+	return &HistoryQueryIterator{results: results}
+}
+
+func (it *HistoryQueryIterator) HasNext() bool {
+	return it.cursor < len(it.results)
+}
+
+func (it *HistoryQueryIterator) Next() (*queryresult.KeyModification, error) {
+	result := it.results[it.cursor]
+	it.cursor++
+	return result, nil
+}
+
+func (it *HistoryQueryIterator) Close() error {
+	return nil
+}
@@ -0,0 +1,60 @@
+package mocks
+
+import (
+	"crypto/x509"
+
+	"github.com/hyperledger/fabric-chaincode-go/pkg/cid"
+	"github.com/hyperledger/fabric-chaincode-go/shim"
+)
+
+// TransactionContext is a fake of contractapi.TransactionContextInterface
+// that hands back a ChaincodeStub and ClientIdentity configured by the test.
+type TransactionContext struct {
+	Stub           *ChaincodeStub
+	ClientIdentity *ClientIdentity
+}
+
+// NewTransactionContext returns a TransactionContext wired to a fresh
+// ChaincodeStub and ClientIdentity.
+func NewTransactionContext() *TransactionContext {
+	return &TransactionContext{
+		Stub:           NewChaincodeStub(),
+		ClientIdentity: &ClientIdentity{},
+	}
+}
+
+func (f *TransactionContext) GetStub() shim.ChaincodeStubInterface {
+	return f.Stub
+}
+
+func (f *TransactionContext) GetClientIdentity() cid.ClientIdentity {
+	return f.ClientIdentity
+}
+
+// ClientIdentity is a fake of cid.ClientIdentity configurable via its MSPID
+// field; tests set it directly to simulate a transaction submitted by a
+// given org.
+type ClientIdentity struct {
+	MSPID string
+	ID    string
+}
+
+func (f *ClientIdentity) GetID() (string, error) {
+	return f.ID, nil
+}
+
+func (f *ClientIdentity) GetMSPID() (string, error) {
+	return f.MSPID, nil
+}
+
+func (f *ClientIdentity) GetAttributeValue(attrName string) (string, bool, error) {
+	return "", false, nil
+}
+
+func (f *ClientIdentity) AssertAttributeValue(attrName, attrValue string) error {
+	return nil
+}
+
+func (f *ClientIdentity) GetX509Certificate() (*x509.Certificate, error) {
+	return nil, nil
+}
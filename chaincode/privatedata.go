@@ -0,0 +1,106 @@
+package chaincode
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+// clinicHistoryPrivateCollection is the collection defined in
+// collections_config.json, shared by Org1MSP and Org2MSP, that holds the
+// PHI fields (PatientName, Description) kept off the public ledger.
+const clinicHistoryPrivateCollection = "clinicHistoryPrivate"
+
+// implicitOrgNotesPrefix, combined with the caller's own MSP ID, names the
+// implicit per-org collection ("_implicit_org_<MSPID>") that Fabric creates
+// automatically for every org on the channel. It is used here to let a
+// clinician leave notes on an asset that are never shared with the other org.
+const implicitOrgNotesPrefix = "_implicit_org_"
+
+// ClinicHistoryPrivateDetails is the PHI payload written to the
+// clinicHistoryPrivate collection. It never touches the world state.
+type ClinicHistoryPrivateDetails struct {
+	ID          string `json:"ID"`
+	PatientName string `json:"PatientName string"`
+	Description string `json:"Description string"`
+}
+
+// putPrivateDetails writes the PHI fields of an asset into the
+// clinicHistoryPrivate collection.
+func putPrivateDetails(ctx contractapi.TransactionContextInterface, id string, patientName string, description string) error {
+	details := ClinicHistoryPrivateDetails{
+		ID:          id,
+		PatientName: patientName,
+		Description: description,
+	}
+	detailsJSON, err := json.Marshal(details)
+	if err != nil {
+		return err
+	}
+
+	return ctx.GetStub().PutPrivateData(clinicHistoryPrivateCollection, id, detailsJSON)
+}
+
+// composePrivateDetails fills in PatientName/Description on an already
+// loaded public asset from the clinicHistoryPrivate collection. If the
+// calling peer is not a member of the collection, GetPrivateData returns nil
+// without an error, and the asset is left with those fields blank.
+func composePrivateDetails(ctx contractapi.TransactionContextInterface, asset *ClinicHistory) error {
+	detailsJSON, err := ctx.GetStub().GetPrivateData(clinicHistoryPrivateCollection, asset.ID)
+	if err != nil {
+		return fmt.Errorf("failed to read private data for asset %s: %v", asset.ID, err)
+	}
+	if detailsJSON == nil {
+		return nil
+	}
+
+	var details ClinicHistoryPrivateDetails
+	if err := json.Unmarshal(detailsJSON, &details); err != nil {
+		return err
+	}
+
+	asset.PatientName = details.PatientName
+	asset.Description = details.Description
+
+	return nil
+}
+
+// implicitOrgNotesCollection returns the name of the calling org's own
+// implicit private data collection.
+func implicitOrgNotesCollection(ctx contractapi.TransactionContextInterface) (string, error) {
+	mspID, err := ctx.GetClientIdentity().GetMSPID()
+	if err != nil {
+		return "", fmt.Errorf("cannot get client's MSP-ID: %v", err)
+	}
+
+	return implicitOrgNotesPrefix + mspID, nil
+}
+
+// PutPrivateNote stores a free-text clinical note about an asset in the
+// caller's own implicit organization collection, visible only to peers of
+// that org - not even to the other org sharing clinicHistoryPrivate.
+func (s *SmartContract) PutPrivateNote(ctx contractapi.TransactionContextInterface, id string, note string) error {
+	collection, err := implicitOrgNotesCollection(ctx)
+	if err != nil {
+		return err
+	}
+
+	return ctx.GetStub().PutPrivateData(collection, id, []byte(note))
+}
+
+// GetPrivateNote returns the calling org's own private note for an asset, or
+// an empty string if none was ever recorded.
+func (s *SmartContract) GetPrivateNote(ctx contractapi.TransactionContextInterface, id string) (string, error) {
+	collection, err := implicitOrgNotesCollection(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	noteBytes, err := ctx.GetStub().GetPrivateData(collection, id)
+	if err != nil {
+		return "", fmt.Errorf("failed to read private note for asset %s: %v", id, err)
+	}
+
+	return string(noteBytes), nil
+}
@@ -0,0 +1,97 @@
+package chaincode
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/hyperledger/fabric-chaincode-go/shim"
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+// PaginatedQueryResult wraps a page of assets together with the bookmark
+// CouchDB handed back, so a client can request the next page by passing
+// that bookmark straight into the next call.
+type PaginatedQueryResult struct {
+	Records             []*ClinicHistory `json:"Records"`
+	FetchedRecordsCount int32            `json:"FetchedRecordsCount"`
+	Bookmark            string           `json:"Bookmark"`
+}
+
+// QueryAssetsByGroup uses a CouchDB rich query to return every asset
+// belonging to the given group. Requires the indexGroupDoc index.
+func (s *SmartContract) QueryAssetsByGroup(ctx contractapi.TransactionContextInterface, group string) ([]*ClinicHistory, error) {
+	queryString := fmt.Sprintf(`{"selector":{"Group":"%s"}}`, group)
+	return getQueryResultForQueryString(ctx, queryString)
+}
+
+// QueryAssetsByState uses a CouchDB rich query to return every asset
+// currently sitting in the given state. Requires the indexStateDoc index.
+func (s *SmartContract) QueryAssetsByState(ctx contractapi.TransactionContextInterface, state int) ([]*ClinicHistory, error) {
+	queryString := fmt.Sprintf(`{"selector":{"State":%d}}`, state)
+	return getQueryResultForQueryString(ctx, queryString)
+}
+
+// QueryAssetsAdvanced runs an arbitrary Mango-style selector passed in by
+// the caller, e.g. {"selector":{"Group":"Lozano","State":{"$lte":3}}}.
+// This is CouchDB-only: it will fail against a LevelDB-backed peer.
+func (s *SmartContract) QueryAssetsAdvanced(ctx contractapi.TransactionContextInterface, queryString string) ([]*ClinicHistory, error) {
+	return getQueryResultForQueryString(ctx, queryString)
+}
+
+// QueryAssetsWithPagination runs the given selector and returns at most
+// pageSize records starting after bookmark, along with the bookmark to use
+// for the next page. Passing an empty bookmark starts from the beginning.
+func (s *SmartContract) QueryAssetsWithPagination(ctx contractapi.TransactionContextInterface, queryString string, pageSize int32, bookmark string) (*PaginatedQueryResult, error) {
+	resultsIterator, responseMetadata, err := ctx.GetStub().GetQueryResultWithPagination(queryString, pageSize, bookmark)
+	if err != nil {
+		return nil, err
+	}
+	defer resultsIterator.Close()
+
+	assets, err := constructAssetsFromIterator(ctx, resultsIterator)
+	if err != nil {
+		return nil, err
+	}
+
+	return &PaginatedQueryResult{
+		Records:             assets,
+		FetchedRecordsCount: responseMetadata.FetchedRecordsCount,
+		Bookmark:            responseMetadata.Bookmark,
+	}, nil
+}
+
+// getQueryResultForQueryString runs a Mango query string against CouchDB
+// and decodes every hit into a ClinicHistory.
+func getQueryResultForQueryString(ctx contractapi.TransactionContextInterface, queryString string) ([]*ClinicHistory, error) {
+	resultsIterator, err := ctx.GetStub().GetQueryResult(queryString)
+	if err != nil {
+		return nil, err
+	}
+	defer resultsIterator.Close()
+
+	return constructAssetsFromIterator(ctx, resultsIterator)
+}
+
+// constructAssetsFromIterator drains a state query iterator, unmarshalling
+// each value as a ClinicHistory and composing in its PHI fields from the
+// clinicHistoryPrivate collection.
+func constructAssetsFromIterator(ctx contractapi.TransactionContextInterface, resultsIterator shim.StateQueryIteratorInterface) ([]*ClinicHistory, error) {
+	var assets []*ClinicHistory
+	for resultsIterator.HasNext() {
+		queryResponse, err := resultsIterator.Next()
+		if err != nil {
+			return nil, err
+		}
+
+		var asset ClinicHistory
+		if err := json.Unmarshal(queryResponse.Value, &asset); err != nil {
+			return nil, err
+		}
+		if err := composePrivateDetails(ctx, &asset); err != nil {
+			return nil, err
+		}
+		assets = append(assets, &asset)
+	}
+
+	return assets, nil
+}
@@ -14,18 +14,37 @@ type SmartContract struct {
 }
 
 // Asset describes basic details of what makes up a simple asset
-//Insert struct field in alphabetic order => to achieve determinism accross languages
+// Insert struct field in alphabetic order => to achieve determinism accross languages
 // golang keeps the order when marshal to json but doesn't order automatically
+// PatientName and Description are PHI: they are never marshalled onto the
+// public ledger (see the "-" tags) and live only in the clinicHistoryPrivate
+// collection, see privatedata.go. The fields stay on this struct so callers
+// keep getting a single, composed view of an asset.
 type ClinicHistory struct {
 	ID          string `json:"ID"`
-	PatientName string `json:"PatientName string"`
-	Description string `json:"Description string"`
-	State       int    `json:"State int"`    //From 1 to 5
-	Group       string `json:"Group string"` //Lozano or ASP
+	PatientName string `json:"-"`
+	Description string `json:"-"`
+	State       int    `json:"State"` //From 1 to 5
+	Group       string `json:"Group"` //Lozano or ASP
 }
 
-// InitLedger adds a base set of assets to the ledger
+// initializedKey guards InitLedger so it can only ever seed the ledger once,
+// decoupling deploy-time init from ordinary invokes per Fabric 2.x lifecycle
+// guidance.
+const initializedKey = "\x00initialized\x00"
+
+// InitLedger adds a base set of assets to the ledger. It may only be called
+// once per channel; a second call returns an error instead of clobbering
+// asset1..asset6.
 func (s *SmartContract) InitLedger(ctx contractapi.TransactionContextInterface) error {
+	initialized, err := ctx.GetStub().GetState(initializedKey)
+	if err != nil {
+		return fmt.Errorf("failed to check ledger initialization: %v", err)
+	}
+	if initialized != nil {
+		return fmt.Errorf("ledger has already been initialized")
+	}
+
 	assets := []ClinicHistory{
 		{ID: "asset1", Description: "Good patient", PatientName: "Pepe", State: 1, Group: "Lozano"},
 		{ID: "asset2", Description: "Good patient", PatientName: "Juan", State: 1, Group: "ASP"},
@@ -45,12 +64,26 @@ func (s *SmartContract) InitLedger(ctx contractapi.TransactionContextInterface)
 		if err != nil {
 			return fmt.Errorf("failed to put to world state. %v", err)
 		}
+
+		if err := putPrivateDetails(ctx, asset.ID, asset.PatientName, asset.Description); err != nil {
+			return err
+		}
+
+		if err := putGroupStateIndex(ctx, asset.Group, asset.State, asset.ID); err != nil {
+			return err
+		}
+		if err := putGroupNameIndex(ctx, asset.Group, asset.PatientName, asset.ID); err != nil {
+			return err
+		}
 	}
 
-	return nil
+	return ctx.GetStub().PutState(initializedKey, []byte{0x01})
 }
 
-// ReadAsset returns the asset stored in the world state with given id.
+// ReadAsset returns the asset stored in the world state with given id,
+// composed with its PHI fields from the clinicHistoryPrivate collection.
+// A peer that is not a member of the collection simply gets back an asset
+// with PatientName/Description left blank, rather than an error.
 func (s *SmartContract) ReadAsset(ctx contractapi.TransactionContextInterface, id string) (*ClinicHistory, error) {
 	assetJSON, err := ctx.GetStub().GetState(id)
 	if err != nil {
@@ -66,18 +99,33 @@ func (s *SmartContract) ReadAsset(ctx contractapi.TransactionContextInterface, i
 		return nil, err
 	}
 
+	if err := composePrivateDetails(ctx, &asset); err != nil {
+		return nil, err
+	}
+
 	return &asset, nil
 }
 
-// CreateAsset issues a new asset to the world state with given details.
-func (s *SmartContract) CreateAsset(ctx contractapi.TransactionContextInterface, id string, patientName string, description string, state int, group string) error {
-	// Checking if the tx is being executed by org1
-	mspID, err := ctx.GetClientIdentity().GetMSPID()
+// ReadAssetHash returns the SHA-256 hash of the private PHI details stored
+// for an asset, letting an org that is not a member of the
+// clinicHistoryPrivate collection verify integrity without ever seeing the
+// underlying PatientName/Description.
+func (s *SmartContract) ReadAssetHash(ctx contractapi.TransactionContextInterface, id string) ([]byte, error) {
+	hash, err := ctx.GetStub().GetPrivateDataHash(clinicHistoryPrivateCollection, id)
 	if err != nil {
-		return errors.New("cannot get client's MSP-ID")
+		return nil, fmt.Errorf("failed to read private data hash: %v", err)
 	}
-	if mspID != "Org1MSP" {
-		return fmt.Errorf("you have no access to this Tx")
+	if hash == nil {
+		return nil, fmt.Errorf("no private data hash for asset %s", id)
+	}
+
+	return hash, nil
+}
+
+// CreateAsset issues a new asset to the world state with given details.
+func (s *SmartContract) CreateAsset(ctx contractapi.TransactionContextInterface, id string, patientName string, description string, state int, group string) error {
+	if err := checkACL(ctx, "CreateAsset"); err != nil {
+		return err
 	}
 
 	exists, err := s.AssetExists(ctx, id)
@@ -89,29 +137,47 @@ func (s *SmartContract) CreateAsset(ctx contractapi.TransactionContextInterface,
 	}
 
 	asset := ClinicHistory{
-		ID:          id,
-		PatientName: patientName,
-		Description: description,
-		State:       state,
-		Group:       group,
+		ID:    id,
+		State: state,
+		Group: group,
 	}
 	assetJSON, err := json.Marshal(asset)
 	if err != nil {
 		return err
 	}
 
-	return ctx.GetStub().PutState(id, assetJSON)
-}
+	if err := ctx.GetStub().PutState(id, assetJSON); err != nil {
+		return err
+	}
 
-// UpdateAsset updates an existing asset in the world state with provided parameters.
-func (s *SmartContract) UpdateAsset(ctx contractapi.TransactionContextInterface, id string, patientName string, description string, state int, group string) error {
-	// Checking if the tx is being executed by org2
-	mspID, err := ctx.GetClientIdentity().GetMSPID()
-	if err != nil {
-		return errors.New("cannot get client's MSP-ID")
+	if err := putPrivateDetails(ctx, id, patientName, description); err != nil {
+		return err
 	}
-	if mspID != "Org2MSP" {
-		return fmt.Errorf("you have no access to this Tx")
+
+	if err := putGroupStateIndex(ctx, group, state, id); err != nil {
+		return err
+	}
+	if err := putGroupNameIndex(ctx, group, patientName, id); err != nil {
+		return err
+	}
+
+	return emitEvent(ctx, eventAssetCreated, asset)
+}
+
+// assetBelongsTo reports whether mspID is the owning org for group: Lozano
+// assets belong to Org1MSP, ASP assets belong to Org2MSP. It backs the
+// ownership guard shared by UpdateAssetMetadata, DeleteAsset and
+// TransferAsset.
+func assetBelongsTo(group string, mspID string) bool {
+	return (group == "Lozano" && mspID == "Org1MSP") || (group == "ASP" && mspID == "Org2MSP")
+}
+
+// UpdateAssetMetadata edits the patient name / description of an existing
+// asset without touching its State or Group. The state-machine move lives
+// in AdvanceAssetState instead.
+func (s *SmartContract) UpdateAssetMetadata(ctx contractapi.TransactionContextInterface, id string, patientName string, description string) error {
+	if err := checkACL(ctx, "UpdateAssetMetadata"); err != nil {
+		return err
 	}
 
 	exists, err := s.AssetExists(ctx, id)
@@ -122,26 +188,33 @@ func (s *SmartContract) UpdateAsset(ctx contractapi.TransactionContextInterface,
 		return fmt.Errorf("the asset %s does not exist", id)
 	}
 
-	//Here we should analize the constraint of the valid sequence of state updating
+	//Here we check if the asset belongs to the org that is trying to edit it
 	asset_existing, err := s.ReadAsset(ctx, id)
-	if err != nil || state == asset_existing.State+1 || (state == asset_existing.State && asset_existing.State == 3) {
+	if err != nil {
 		return err
 	}
-
-	// overwriting original asset with new asset
-	asset := ClinicHistory{
-		ID:          id,
-		PatientName: patientName,
-		Description: description,
-		State:       state,
-		Group:       group,
-	}
-	assetJSON, err := json.Marshal(asset)
+	mspID, err := ctx.GetClientIdentity().GetMSPID()
 	if err != nil {
+		return errors.New("cannot get client's MSP-ID")
+	}
+	if !assetBelongsTo(asset_existing.Group, mspID) {
+		return errors.New("asset does not belong to the executing org")
+	}
+
+	if err := putPrivateDetails(ctx, id, patientName, description); err != nil {
 		return err
 	}
 
-	return ctx.GetStub().PutState(id, assetJSON)
+	if patientName != asset_existing.PatientName {
+		if err := delGroupNameIndex(ctx, asset_existing.Group, asset_existing.PatientName, id); err != nil {
+			return err
+		}
+		if err := putGroupNameIndex(ctx, asset_existing.Group, patientName, id); err != nil {
+			return err
+		}
+	}
+
+	return nil
 }
 
 // DeleteAsset deletes an given asset from the world state.
@@ -163,11 +236,26 @@ func (s *SmartContract) DeleteAsset(ctx contractapi.TransactionContextInterface,
 	if err != nil {
 		return errors.New("cannot get client's MSP-ID")
 	}
-	if (asset_existing.Group == "ASP" && mspID == "Org2MSP") || (asset_existing.Group == "Lozano" && mspID == "Org1MSP") {
+	if !assetBelongsTo(asset_existing.Group, mspID) {
 		return errors.New("asset does not belong to the executing org")
 	}
 
-	return ctx.GetStub().DelState(id)
+	if err := ctx.GetStub().DelState(id); err != nil {
+		return err
+	}
+
+	if err := ctx.GetStub().DelPrivateData(clinicHistoryPrivateCollection, id); err != nil {
+		return err
+	}
+
+	if err := delGroupStateIndex(ctx, asset_existing.Group, asset_existing.State, id); err != nil {
+		return err
+	}
+	if err := delGroupNameIndex(ctx, asset_existing.Group, asset_existing.PatientName, id); err != nil {
+		return err
+	}
+
+	return emitEvent(ctx, eventAssetDeleted, assetDeletedPayload{ID: id})
 }
 
 // AssetExists returns true when asset with given ID exists in world state
@@ -192,7 +280,7 @@ func (s *SmartContract) TransferAsset(ctx contractapi.TransactionContextInterfac
 	if err != nil {
 		return "", errors.New("cannot get client's MSP-ID")
 	}
-	if (asset.Group == "ASP" && mspID == "Org2MSP") || (asset.Group == "Lozano" && mspID == "Org1MSP") || asset.Group == newGroup {
+	if !assetBelongsTo(asset.Group, mspID) || asset.Group == newGroup {
 		return "", errors.New("invalid tx order")
 	}
 
@@ -209,6 +297,28 @@ func (s *SmartContract) TransferAsset(ctx contractapi.TransactionContextInterfac
 		return "", err
 	}
 
+	if err := delGroupStateIndex(ctx, oldGroup, asset.State, id); err != nil {
+		return "", err
+	}
+	if err := putGroupStateIndex(ctx, newGroup, asset.State, id); err != nil {
+		return "", err
+	}
+	if err := delGroupNameIndex(ctx, oldGroup, asset.PatientName, id); err != nil {
+		return "", err
+	}
+	if err := putGroupNameIndex(ctx, newGroup, asset.PatientName, id); err != nil {
+		return "", err
+	}
+
+	if err := emitEvent(ctx, eventAssetTransferred, assetTransferredPayload{
+		ID:       id,
+		OldGroup: oldGroup,
+		NewGroup: newGroup,
+		MSPID:    mspID,
+	}); err != nil {
+		return "", err
+	}
+
 	return oldGroup, nil
 }
 
@@ -230,8 +340,15 @@ func (s *SmartContract) GetAllAssets(ctx contractapi.TransactionContextInterface
 		}
 
 		var asset ClinicHistory
-		err = json.Unmarshal(queryResponse.Value, &asset)
-		if err != nil {
+		// The chaincode namespace also holds non-asset entries - the
+		// initializedKey marker, ACL config (see acl.go) and the
+		// group~state~id/group~name~id composite-key index markers (see
+		// index.go) - none of which unmarshal into a ClinicHistory, so skip
+		// them rather than aborting the whole range scan.
+		if err := json.Unmarshal(queryResponse.Value, &asset); err != nil {
+			continue
+		}
+		if err := composePrivateDetails(ctx, &asset); err != nil {
 			return nil, err
 		}
 		assets = append(assets, &asset)
@@ -258,13 +375,17 @@ func (s *SmartContract) GetAllAssetsFromGroup(ctx contractapi.TransactionContext
 		}
 
 		var asset ClinicHistory
-		err = json.Unmarshal(queryResponse.Value, &asset)
-		if err != nil {
-			return nil, err
+		// See the comment in GetAllAssets: skip non-asset entries that share
+		// the chaincode namespace instead of failing the whole scan.
+		if err := json.Unmarshal(queryResponse.Value, &asset); err != nil {
+			continue
 		}
 
 		//If the current asset belongs to the given group, then append it to the list
 		if asset.Group == group {
+			if err := composePrivateDetails(ctx, &asset); err != nil {
+				return nil, err
+			}
 			assets = append(assets, &asset)
 		}
 	}
@@ -0,0 +1,43 @@
+package chaincode_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tingui96/cc-medicalregisters-go/chaincode"
+	"github.com/tingui96/cc-medicalregisters-go/chaincode/mocks"
+)
+
+func TestUpdateAssetMetadataRestrictsByGroupOwnership(t *testing.T) {
+	transactionContext := mocks.NewTransactionContext()
+	transactionContext.ClientIdentity.MSPID = "Org1MSP"
+
+	contract := chaincode.SmartContract{}
+	require.NoError(t, contract.CreateAsset(transactionContext, "asset1", "Pepe", "Good patient", 1, "Lozano"))
+
+	// Org1MSP owns Lozano, so it may edit the record it owns.
+	assert.NoError(t, contract.UpdateAssetMetadata(transactionContext, "asset1", "Pepito", "Good patient"))
+
+	// Org2MSP passes the UpdateAssetMetadata ACL but does not own Lozano,
+	// so it must be rejected by the ownership guard, not the ACL.
+	transactionContext.ClientIdentity.MSPID = "Org2MSP"
+	err := contract.UpdateAssetMetadata(transactionContext, "asset1", "Ana", "Good patient")
+	assert.Error(t, err, "Org2MSP does not own Lozano assets")
+}
+
+func TestDeleteAssetRestrictsByGroupOwnership(t *testing.T) {
+	transactionContext := mocks.NewTransactionContext()
+	transactionContext.ClientIdentity.MSPID = "Org1MSP"
+
+	contract := chaincode.SmartContract{}
+	require.NoError(t, contract.CreateAsset(transactionContext, "asset1", "Pepe", "Good patient", 1, "Lozano"))
+
+	transactionContext.ClientIdentity.MSPID = "Org2MSP"
+	err := contract.DeleteAsset(transactionContext, "asset1")
+	assert.Error(t, err, "Org2MSP does not own Lozano assets")
+
+	transactionContext.ClientIdentity.MSPID = "Org1MSP"
+	assert.NoError(t, contract.DeleteAsset(transactionContext, "asset1"))
+}
@@ -0,0 +1,100 @@
+package chaincode
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+// stateMachine maps a ClinicHistory's current State (1 to 5) to the set of
+// States it is legal to move to next. State 3 is the only one with a
+// self-loop, representing a clinical hold that can either stay put or
+// advance once cleared.
+var stateMachine = map[int][]int{
+	1: {2},
+	2: {3},
+	3: {3, 4},
+	4: {5},
+	5: {},
+}
+
+// isValidTransition reports whether moving an asset from "from" to "to" is
+// allowed by stateMachine.
+func isValidTransition(from int, to int) bool {
+	for _, allowed := range stateMachine[from] {
+		if allowed == to {
+			return true
+		}
+	}
+
+	return false
+}
+
+// GetAllowedTransitions returns the States an asset may legally move to from
+// its current State, per stateMachine.
+func (s *SmartContract) GetAllowedTransitions(ctx contractapi.TransactionContextInterface, id string) ([]int, error) {
+	asset, err := s.ReadAsset(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	allowed := stateMachine[asset.State]
+	transitions := make([]int, len(allowed))
+	copy(transitions, allowed)
+
+	return transitions, nil
+}
+
+// AdvanceAssetState moves an asset to newState, rejecting any move that
+// violates stateMachine. This is the only way to change an asset's State;
+// UpdateAssetMetadata handles patient name / description edits separately.
+func (s *SmartContract) AdvanceAssetState(ctx contractapi.TransactionContextInterface, id string, newState int) error {
+	if err := checkACL(ctx, "AdvanceAssetState"); err != nil {
+		return err
+	}
+
+	exists, err := s.AssetExists(ctx, id)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return fmt.Errorf("the asset %s does not exist", id)
+	}
+
+	asset_existing, err := s.ReadAsset(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if !isValidTransition(asset_existing.State, newState) {
+		return fmt.Errorf("cannot move asset %s from state %d to state %d", id, asset_existing.State, newState)
+	}
+
+	asset := ClinicHistory{
+		ID:    id,
+		State: newState,
+		Group: asset_existing.Group,
+	}
+	assetJSON, err := json.Marshal(asset)
+	if err != nil {
+		return err
+	}
+
+	if err := ctx.GetStub().PutState(id, assetJSON); err != nil {
+		return err
+	}
+
+	if err := delGroupStateIndex(ctx, asset_existing.Group, asset_existing.State, id); err != nil {
+		return err
+	}
+	if err := putGroupStateIndex(ctx, asset_existing.Group, newState, id); err != nil {
+		return err
+	}
+
+	return emitEvent(ctx, eventAssetStateChanged, assetStateChangedPayload{
+		ID:       id,
+		OldState: asset_existing.State,
+		NewState: newState,
+	})
+}
@@ -0,0 +1,103 @@
+package chaincode_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tingui96/cc-medicalregisters-go/chaincode"
+	"github.com/tingui96/cc-medicalregisters-go/chaincode/mocks"
+)
+
+// seedAsset writes a ClinicHistory directly into the stub's world state,
+// bypassing CreateAsset so these tests only exercise the state machine.
+func seedAsset(t *testing.T, stub *mocks.ChaincodeStub, id string, state int, group string) {
+	t.Helper()
+
+	assetJSON, err := json.Marshal(struct {
+		ID    string `json:"ID"`
+		State int    `json:"State"`
+		Group string `json:"Group"`
+	}{ID: id, State: state, Group: group})
+	require.NoError(t, err)
+
+	require.NoError(t, stub.PutState(id, assetJSON))
+}
+
+func TestAdvanceAssetStateTransitions(t *testing.T) {
+	tests := []struct {
+		name    string
+		from    int
+		to      int
+		wantErr bool
+	}{
+		{"1 to 2 is legal", 1, 2, false},
+		{"1 to 3 skips a step", 1, 3, true},
+		{"2 to 3 is legal", 2, 3, false},
+		{"2 to 1 goes backwards", 2, 1, true},
+		{"3 to 3 hold is legal", 3, 3, false},
+		{"3 to 4 clears the hold", 3, 4, false},
+		{"3 to 5 skips a step", 3, 5, true},
+		{"4 to 5 is legal", 4, 5, false},
+		{"5 to 5 is terminal", 5, 5, true},
+		{"5 to 1 is terminal", 5, 1, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transactionContext := mocks.NewTransactionContext()
+			transactionContext.ClientIdentity.MSPID = "Org2MSP"
+			seedAsset(t, transactionContext.Stub, "asset1", tt.from, "Lozano")
+
+			contract := chaincode.SmartContract{}
+			err := contract.AdvanceAssetState(transactionContext, "asset1", tt.to)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			asset, err := contract.ReadAsset(transactionContext, "asset1")
+			require.NoError(t, err)
+			assert.Equal(t, tt.to, asset.State)
+		})
+	}
+}
+
+func TestAdvanceAssetStateRejectsNonOrg2(t *testing.T) {
+	transactionContext := mocks.NewTransactionContext()
+	transactionContext.ClientIdentity.MSPID = "Org1MSP"
+	seedAsset(t, transactionContext.Stub, "asset1", 1, "Lozano")
+
+	contract := chaincode.SmartContract{}
+	err := contract.AdvanceAssetState(transactionContext, "asset1", 2)
+
+	assert.Error(t, err)
+}
+
+func TestGetAllowedTransitions(t *testing.T) {
+	tests := []struct {
+		state int
+		want  []int
+	}{
+		{1, []int{2}},
+		{2, []int{3}},
+		{3, []int{3, 4}},
+		{4, []int{5}},
+		{5, []int{}},
+	}
+
+	for _, tt := range tests {
+		transactionContext := mocks.NewTransactionContext()
+		seedAsset(t, transactionContext.Stub, "asset1", tt.state, "Lozano")
+
+		contract := chaincode.SmartContract{}
+		got, err := contract.GetAllowedTransitions(transactionContext, "asset1")
+
+		require.NoError(t, err)
+		assert.ElementsMatch(t, tt.want, got)
+	}
+}